@@ -0,0 +1,156 @@
+package mango
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Validator is implemented by models that know how to validate their own
+// state once Context.Bind has populated them. If the target passed to
+// Bind implements Validator, its error is wrapped in the error returned
+// from Bind.
+type Validator interface {
+	Validate() error
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// valueLookup resolves a field name to its source value, reporting
+// whether a value was found at all.
+type valueLookup func(name string) (string, bool)
+
+// caseInsensitiveLookup builds a valueLookup over values that matches
+// names case-insensitively.
+func caseInsensitiveLookup(values map[string]string) valueLookup {
+	lower := make(map[string]string, len(values))
+	for k, v := range values {
+		lower[strings.ToLower(k)] = v
+	}
+	return func(name string) (string, bool) {
+		v, ok := lower[strings.ToLower(name)]
+		return v, ok
+	}
+}
+
+// headerLookup builds a valueLookup over h, relying on http.Header.Get's
+// own case-insensitive canonicalization.
+func headerLookup(h http.Header) valueLookup {
+	return func(name string) (string, bool) {
+		v := h.Get(name)
+		if v == "" {
+			return "", false
+		}
+		return v, true
+	}
+}
+
+// flattenQueryValues reduces a url.Values to its first value per key, so
+// it can be matched the same way as RouteParams.
+func flattenQueryValues(values url.Values) map[string]string {
+	m := make(map[string]string, len(values))
+	for k, v := range values {
+		if len(v) > 0 {
+			m[k] = v[0]
+		}
+	}
+	return m
+}
+
+// bindStruct walks the exported fields of m, a pointer to a struct, and
+// fills any that are still zero-valued using lookup. A field's lookup key
+// is taken from its `tag:"name"` struct tag if present, otherwise its Go
+// field name.
+func bindStruct(m interface{}, tag string, lookup valueLookup) error {
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("bind target must be a pointer to a struct")
+	}
+	v = v.Elem()
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+		fv := v.Field(i)
+		if !fv.IsZero() {
+			continue
+		}
+
+		name := field.Name
+		if tagVal := field.Tag.Get(tag); tagVal != "" {
+			name = tagVal
+		}
+
+		raw, ok := lookup(name)
+		if !ok {
+			continue
+		}
+		if err := setFieldValue(fv, raw); err != nil {
+			return fmt.Errorf("field %s: %v", field.Name, err)
+		}
+	}
+	return nil
+}
+
+// setFieldValue converts raw into fv's type and assigns it.
+func setFieldValue(fv reflect.Value, raw string) error {
+	if fv.Type() == timeType {
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return err
+		}
+		fv.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	if fv.Kind() == reflect.Slice {
+		parts := strings.Split(raw, ",")
+		slice := reflect.MakeSlice(fv.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := setFieldValue(slice.Index(i), strings.TrimSpace(part)); err != nil {
+				return err
+			}
+		}
+		fv.Set(slice)
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported field type %s", fv.Type())
+	}
+	return nil
+}
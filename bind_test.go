@@ -0,0 +1,153 @@
+package mango
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBindPopulatesFromRouteParams(t *testing.T) {
+	type model struct {
+		ID string `route:"id"`
+	}
+	req, _ := http.NewRequest("GET", "/widgets/42", nil)
+	ctx := &Context{Request: req, RouteParams: map[string]string{"id": "42"}}
+
+	var m model
+	if err := ctx.Bind(&m); err != nil {
+		t.Fatalf("Bind returned error: %v", err)
+	}
+	if m.ID != "42" {
+		t.Errorf("ID = %q, want %q", m.ID, "42")
+	}
+}
+
+func TestBindPopulatesFromQueryParams(t *testing.T) {
+	type model struct {
+		Page int `query:"page"`
+	}
+	req, _ := http.NewRequest("GET", "/widgets?page=3", nil)
+	ctx := &Context{Request: req}
+
+	var m model
+	if err := ctx.Bind(&m); err != nil {
+		t.Fatalf("Bind returned error: %v", err)
+	}
+	if m.Page != 3 {
+		t.Errorf("Page = %d, want %d", m.Page, 3)
+	}
+}
+
+func TestBindPopulatesFromHeader(t *testing.T) {
+	type model struct {
+		Trace string `header:"X-Trace"`
+	}
+	req, _ := http.NewRequest("GET", "/widgets", nil)
+	req.Header.Set("X-Trace", "abc-123")
+	ctx := &Context{Request: req}
+
+	var m model
+	if err := ctx.Bind(&m); err != nil {
+		t.Fatalf("Bind returned error: %v", err)
+	}
+	if m.Trace != "abc-123" {
+		t.Errorf("Trace = %q, want %q", m.Trace, "abc-123")
+	}
+}
+
+func TestBindMatchesFieldNameWhenNoTag(t *testing.T) {
+	type model struct {
+		Page int
+	}
+	req, _ := http.NewRequest("GET", "/widgets?page=7", nil)
+	ctx := &Context{Request: req}
+
+	var m model
+	if err := ctx.Bind(&m); err != nil {
+		t.Fatalf("Bind returned error: %v", err)
+	}
+	if m.Page != 7 {
+		t.Errorf("Page = %d, want %d", m.Page, 7)
+	}
+}
+
+func TestBindDoesNotOverwriteFieldAlreadySetByBody(t *testing.T) {
+	type model struct {
+		Name string `json:"name"`
+		Page int    `query:"page"`
+	}
+	req, _ := http.NewRequest("POST", "/widgets?page=3", strings.NewReader(`{"name":"mango","page":9}`))
+	req.Header.Set("Content-Type", "application/json")
+	ctx := &Context{Request: req, encoderEngine: newEncoderEngine()}
+
+	var m model
+	if err := ctx.Bind(&m); err != nil {
+		t.Fatalf("Bind returned error: %v", err)
+	}
+	if m.Page != 9 {
+		t.Errorf("Page = %d, want %d (body value should win over query)", m.Page, 9)
+	}
+}
+
+func TestBindPopulatesSliceFromCommaSeparatedQueryParam(t *testing.T) {
+	type model struct {
+		IDs []int `query:"ids"`
+	}
+	req, _ := http.NewRequest("GET", "/widgets?ids=1,2,3", nil)
+	ctx := &Context{Request: req}
+
+	var m model
+	if err := ctx.Bind(&m); err != nil {
+		t.Fatalf("Bind returned error: %v", err)
+	}
+	want := []int{1, 2, 3}
+	if len(m.IDs) != len(want) {
+		t.Fatalf("IDs = %v, want %v", m.IDs, want)
+	}
+	for i := range want {
+		if m.IDs[i] != want[i] {
+			t.Errorf("IDs[%d] = %d, want %d", i, m.IDs[i], want[i])
+		}
+	}
+}
+
+func TestBindPopulatesRFC3339TimeFromQueryParam(t *testing.T) {
+	type model struct {
+		Since time.Time `query:"since"`
+	}
+	req, _ := http.NewRequest("GET", "/widgets?since=2024-01-02T15:04:05Z", nil)
+	ctx := &Context{Request: req}
+
+	var m model
+	if err := ctx.Bind(&m); err != nil {
+		t.Fatalf("Bind returned error: %v", err)
+	}
+	want, _ := time.Parse(time.RFC3339, "2024-01-02T15:04:05Z")
+	if !m.Since.Equal(want) {
+		t.Errorf("Since = %v, want %v", m.Since, want)
+	}
+}
+
+type validatedModel struct {
+	Page int `query:"page"`
+}
+
+func (m validatedModel) Validate() error {
+	if m.Page < 1 {
+		return fmt.Errorf("page must be positive")
+	}
+	return nil
+}
+
+func TestBindPropagatesValidatorError(t *testing.T) {
+	req, _ := http.NewRequest("GET", "/widgets?page=0", nil)
+	ctx := &Context{Request: req}
+
+	var m validatedModel
+	err := ctx.Bind(&m)
+	if err == nil {
+		t.Fatalf("expected Bind to return an error")
+	}
+}
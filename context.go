@@ -13,6 +13,7 @@ type Response struct {
 	context *Context
 	model   interface{}
 	status  int
+	err     error
 }
 
 // WithModel sets the Model that will be serialized for the response.
@@ -21,7 +22,6 @@ type Response struct {
 // This method returns the Response object and can be chained.
 func (r *Response) WithModel(m interface{}) *Response {
 	r.context.model = m
-	r.context.responseReady = true
 	return r
 }
 
@@ -29,7 +29,6 @@ func (r *Response) WithModel(m interface{}) *Response {
 // This method returns the Response object and can be chained.
 func (r *Response) WithStatus(s int) *Response {
 	r.context.status = s
-	r.context.responseReady = true
 	return r
 }
 
@@ -62,7 +61,14 @@ type Context struct {
 	encoderEngine EncoderEngine
 	Reader        io.ReadCloser
 	Identity      Identity
-	responseReady bool
+	// RequestID is populated by middleware such as the middleware package's
+	// RequestID hook. It is empty unless such a hook has run.
+	RequestID string
+	// streaming is set once a handler starts writing its response body
+	// directly (WithStream, SSE, ServeFile, ServeContent), suppressing
+	// the usual model encoding pipeline in Router.writeResponse.
+	streaming bool
+	sseID     int
 }
 
 // ContextHandlerFunc type is an adapter to allow the use of ordinary
@@ -76,6 +82,12 @@ func (f ContextHandlerFunc) ServeHTTP(c *Context) {
 	f(c)
 }
 
+// ContextReturnHandlerFunc is a handler that returns an error instead of
+// handling it itself. A non-nil error is translated into a response by the
+// Router's ErrorHandler, letting handlers propagate failures (for example
+// from ctx.Bind) without calling ctx.Error directly.
+type ContextReturnHandlerFunc func(*Context) error
+
 // Respond returns a new context based Response object.
 func (c *Context) Respond() *Response {
 	return &Response{context: c}
@@ -102,7 +114,6 @@ func (c *Context) RespondWith(d interface{}) *Response {
 	default: //must be a model
 		c.model = d
 	}
-	c.responseReady = true
 	return response
 }
 
@@ -127,6 +138,21 @@ func (c *Context) Error(msg string, code int) {
 	http.Error(c.Writer, msg, code)
 }
 
+// ErrorWith sends problem as the response body, using problem.Status as
+// the HTTP status code. The body is serialized through the encoderEngine
+// using the request's Accept header, falling back to a plain text body of
+// problem.Detail when no encoder matches.
+// Request handlers should cease execution after calling this method.
+func (c *Context) ErrorWith(problem *Problem) {
+	if mt, body, ok := negotiateProblem(c.encoderEngine, c.Request, problem); ok {
+		c.Writer.Header().Set("Content-Type", mt)
+		c.Writer.WriteHeader(problem.Status)
+		c.Writer.Write(body)
+		return
+	}
+	http.Error(c.Writer, problem.Detail, problem.Status)
+}
+
 // Redirect sends a redirect response using the specified URL and HTTP
 // status.
 // Request handlers should cease execution after calling this method.
@@ -153,7 +179,16 @@ func (c *Context) contentDecoder() (Decoder, error) {
 }
 
 func (c *Context) acceptableMediaTypes() []string {
-	hdr := c.Request.Header.Get("Accept")
+	return acceptHeaderMediaTypes(c.Request)
+}
+
+// acceptHeaderMediaTypes parses req's Accept header into a list of media
+// types, sorted by descending quality value.
+func acceptHeaderMediaTypes(req *http.Request) []string {
+	hdr := req.Header.Get("Accept")
+	if hdr == "" {
+		return []string{"*/*"}
+	}
 	hdr = strings.Replace(hdr, " ", "", -1)
 	types := strings.Split(hdr, ",")
 	mt := make(mediaTypes, len(types))
@@ -198,28 +233,49 @@ func (c *Context) GetEncoder() (Encoder, string, error) {
 	return nil, mt, err
 }
 
-// Bind populates the supplied model with data from the request.
-// This is performed in stages. initially, any requestbody content is
-// deserialized.
+// Bind populates the supplied model with data from the request. m must be
+// a pointer to a struct.
 //
-// TODO: Following is not yet implemented:
+// This is performed in stages. If the request has a Content-Type, its
+// body is deserialized first. Route parameters are then used to populate
+// any exported field that is still zero-valued, followed by the request's
+// query string, followed by its headers.
 //
-// Route parameters are used next to populate any unset members.
-// Finally, query parameters are used to populate any remaining unset members.
+// By default a field is matched by name, case-insensitively; this can be
+// overridden with a `route:"name"`, `query:"name"` or `header:"Name"`
+// struct tag. Supported field types are string, the signed and unsigned
+// integer and float kinds, bool, time.Time (parsed as RFC3339) and slices
+// of any of those (populated by splitting the value on commas).
 //
-// This method is under review - currently Binding only uses deserialized
-// request body content.
+// If m implements Validator, its Validate method is called last and any
+// error it returns is wrapped in the error returned from Bind, so
+// errors.Is and errors.As can find it.
 func (c *Context) Bind(m interface{}) error {
-	decoder, err := c.contentDecoder()
-	if err != nil {
+	if ct := c.Request.Header.Get("Content-Type"); ct != "" {
+		decoder, err := c.contentDecoder()
+		if err != nil {
+			return fmt.Errorf("unable to bind: %v", err)
+		}
+		if err := decoder.Decode(m); err != nil {
+			return fmt.Errorf("unable to bind: %v", err)
+		}
+	}
+
+	if err := bindStruct(m, "route", caseInsensitiveLookup(c.RouteParams)); err != nil {
 		return fmt.Errorf("unable to bind: %v", err)
 	}
-	err = decoder.Decode(m)
-	if err != nil {
+	if err := bindStruct(m, "query", caseInsensitiveLookup(flattenQueryValues(c.Request.URL.Query()))); err != nil {
+		return fmt.Errorf("unable to bind: %v", err)
+	}
+	if err := bindStruct(m, "header", headerLookup(c.Request.Header)); err != nil {
 		return fmt.Errorf("unable to bind: %v", err)
 	}
 
-	// TODO: now update any missing empty properties from url path/query params
+	if v, ok := m.(Validator); ok {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("unable to bind: %w", err)
+		}
+	}
 
 	return nil
 }
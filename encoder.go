@@ -0,0 +1,94 @@
+package mango
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// DefaultMediaType is the media type used to select an encoder when a
+// request has no Accept header, or Accept is "*/*".
+const DefaultMediaType = "application/json"
+
+// Encoder serializes a model to the io.Writer it was created with.
+type Encoder interface {
+	Encode(v interface{}) error
+}
+
+// Decoder deserializes request body content into a model.
+type Decoder interface {
+	Decode(v interface{}) error
+}
+
+// EncoderMaker creates an Encoder bound to the supplied io.Writer.
+type EncoderMaker func(w io.Writer) Encoder
+
+// DecoderMaker creates a Decoder bound to the supplied io.Reader.
+type DecoderMaker func(r io.Reader) Decoder
+
+// EncoderEngine resolves Encoders and Decoders by media type.
+type EncoderEngine interface {
+	// RegisterEncoder associates an EncoderMaker with a media type.
+	RegisterEncoder(mediaType string, maker EncoderMaker)
+	// RegisterDecoder associates a DecoderMaker with a media type.
+	RegisterDecoder(mediaType string, maker DecoderMaker)
+	// GetEncoder returns an Encoder for the supplied media type, bound to w.
+	GetEncoder(w io.Writer, mediaType string) (Encoder, error)
+	// GetDecoder returns a Decoder for the supplied media type, bound to r.
+	GetDecoder(r io.Reader, mediaType string) (Decoder, error)
+	// DefaultMediaType returns the media type used when a request has no
+	// Accept header, or Accept is "*/*".
+	DefaultMediaType() string
+}
+
+// encoderEngine is the default EncoderEngine implementation. It registers
+// application/json support out of the box.
+type encoderEngine struct {
+	defaultMediaType string
+	encoders         map[string]EncoderMaker
+	decoders         map[string]DecoderMaker
+}
+
+// newEncoderEngine creates an encoderEngine with JSON support registered
+// and DefaultMediaType set as its default media type.
+func newEncoderEngine() *encoderEngine {
+	e := &encoderEngine{
+		defaultMediaType: DefaultMediaType,
+		encoders:         make(map[string]EncoderMaker),
+		decoders:         make(map[string]DecoderMaker),
+	}
+	e.RegisterEncoder(DefaultMediaType, func(w io.Writer) Encoder { return json.NewEncoder(w) })
+	e.RegisterDecoder(DefaultMediaType, func(r io.Reader) Decoder { return json.NewDecoder(r) })
+	e.RegisterEncoder("application/problem+json", func(w io.Writer) Encoder { return json.NewEncoder(w) })
+	e.RegisterEncoder("application/problem+xml", func(w io.Writer) Encoder { return xml.NewEncoder(w) })
+	return e
+}
+
+func (e *encoderEngine) RegisterEncoder(mediaType string, maker EncoderMaker) {
+	e.encoders[mediaType] = maker
+}
+
+func (e *encoderEngine) RegisterDecoder(mediaType string, maker DecoderMaker) {
+	e.decoders[mediaType] = maker
+}
+
+func (e *encoderEngine) GetEncoder(w io.Writer, mediaType string) (Encoder, error) {
+	maker, ok := e.encoders[mediaType]
+	if !ok {
+		return nil, fmt.Errorf("no encoder registered for media type %q", mediaType)
+	}
+	return maker(w), nil
+}
+
+func (e *encoderEngine) GetDecoder(r io.Reader, mediaType string) (Decoder, error) {
+	maker, ok := e.decoders[mediaType]
+	if !ok {
+		return nil, fmt.Errorf("no decoder registered for media type %q", mediaType)
+	}
+	return maker(r), nil
+}
+
+func (e *encoderEngine) DefaultMediaType() string {
+	return e.defaultMediaType
+}
@@ -0,0 +1,57 @@
+package mango
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// HTTPError is an error that carries the HTTP status code and message that
+// should be sent to the client in response to it. Handlers registered with
+// GetR, PostR, etc. can return one directly to control the response; any
+// other error is handled as an opaque 500.
+type HTTPError struct {
+	Code    int
+	Message string
+	Cause   error
+}
+
+// Error implements the error interface.
+func (e *HTTPError) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Cause)
+	}
+	return e.Message
+}
+
+// Unwrap returns the underlying cause, if any, so errors.Is/As can see
+// through an HTTPError to whatever triggered it.
+func (e *HTTPError) Unwrap() error {
+	return e.Cause
+}
+
+// Sentinel errors for the common client error responses. They are
+// themselves *HTTPError values, so returning one from a ContextReturnHandlerFunc
+// or pre-hook is enough to produce the matching status code and message.
+var (
+	ErrBadRequest   = &HTTPError{Code: http.StatusBadRequest, Message: "Bad Request"}
+	ErrUnauthorized = &HTTPError{Code: http.StatusUnauthorized, Message: "Unauthorized"}
+	ErrForbidden    = &HTTPError{Code: http.StatusForbidden, Message: "Forbidden"}
+	ErrNotFound     = &HTTPError{Code: http.StatusNotFound, Message: "Not Found"}
+	ErrConflict     = &HTTPError{Code: http.StatusConflict, Message: "Conflict"}
+)
+
+// ErrorHandler translates an error returned from a ContextReturnHandlerFunc,
+// or from a pre-hook, into a response written to ctx.
+type ErrorHandler func(ctx *Context, err error)
+
+// DefaultErrorHandler is the ErrorHandler used when a Router's ErrorHandler
+// field is unset. An *HTTPError is sent as-is; any other error is hidden
+// behind a generic 500 response. Both are sent as a Problem, serialized
+// according to the request's Accept header.
+func DefaultErrorHandler(ctx *Context, err error) {
+	if httpErr, ok := err.(*HTTPError); ok {
+		ctx.ErrorWith(&Problem{Title: http.StatusText(httpErr.Code), Status: httpErr.Code, Detail: httpErr.Message})
+		return
+	}
+	ctx.ErrorWith(&Problem{Title: http.StatusText(http.StatusInternalServerError), Status: http.StatusInternalServerError, Detail: "Sorry, something went wrong."})
+}
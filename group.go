@@ -0,0 +1,128 @@
+package mango
+
+// RouteGroup is a sub-router sharing its parent Router's route table and
+// encoderEngine, but with its own path prefix and its own pre/post-hook
+// chain. A RouteGroup's hooks run after the hooks of the RouteGroup it
+// was created from, and its pre-hooks run after the Router's pre-hooks.
+// Group hooks run as part of the matched handler, so a group's post-hooks
+// run before the Router's own post-hooks.
+//
+// RouteGroups are created with Router.Group or RouteGroup.Group, and
+// expose the same registration surface as Router so they can be used as a
+// drop-in replacement when wiring up routes.
+type RouteGroup struct {
+	prefix        string
+	routes        routeTable
+	preHooks      []PreHookFunc
+	postHooks     []PostHookFunc
+	encoderEngine EncoderEngine
+	// router is used to route a pre-hook error to the same ErrorHandler a
+	// Router's own pre-hooks use, so a group behaves identically to a
+	// Router when a hook such as middleware.BasicAuth rejects a request.
+	router *Router
+}
+
+// Group returns a RouteGroup rooted at prefix. Handlers registered on the
+// group are added to the Router under prefix+pattern. The Router's own
+// hooks already run for every request via Router.ServeHTTP, so the group
+// only needs to carry its own hooks: its pre-hooks run after the Router's
+// pre-hooks, and its post-hooks run before the Router's post-hooks.
+func (rtr *Router) Group(prefix string) *RouteGroup {
+	if rtr.routes == nil {
+		rtr.routes = newTree()
+	}
+	return &RouteGroup{
+		prefix:        prefix,
+		routes:        rtr.routes,
+		encoderEngine: rtr.encoderEngine,
+		router:        rtr,
+	}
+}
+
+// Group returns a nested RouteGroup rooted at g.prefix+prefix, inheriting
+// g's hooks ahead of any hooks added to the nested group.
+func (g *RouteGroup) Group(prefix string) *RouteGroup {
+	return &RouteGroup{
+		prefix:        g.prefix + prefix,
+		routes:        g.routes,
+		preHooks:      append([]PreHookFunc{}, g.preHooks...),
+		postHooks:     append([]PostHookFunc{}, g.postHooks...),
+		encoderEngine: g.encoderEngine,
+		router:        g.router,
+	}
+}
+
+// Get registers handler to be called for GET requests matching
+// g.prefix+pattern.
+func (g *RouteGroup) Get(pattern string, handler ContextHandlerFunc) {
+	g.addRoute(pattern, "GET", handler)
+}
+
+// Post registers handler to be called for POST requests matching
+// g.prefix+pattern.
+func (g *RouteGroup) Post(pattern string, handler ContextHandlerFunc) {
+	g.addRoute(pattern, "POST", handler)
+}
+
+// Put registers handler to be called for PUT requests matching
+// g.prefix+pattern.
+func (g *RouteGroup) Put(pattern string, handler ContextHandlerFunc) {
+	g.addRoute(pattern, "PUT", handler)
+}
+
+// Patch registers handler to be called for PATCH requests matching
+// g.prefix+pattern.
+func (g *RouteGroup) Patch(pattern string, handler ContextHandlerFunc) {
+	g.addRoute(pattern, "PATCH", handler)
+}
+
+// Del registers handler to be called for DELETE requests matching
+// g.prefix+pattern.
+func (g *RouteGroup) Del(pattern string, handler ContextHandlerFunc) {
+	g.addRoute(pattern, "DELETE", handler)
+}
+
+// Options registers handler to be called for OPTIONS requests matching
+// g.prefix+pattern. This is most useful for middleware such as CORS
+// preflight handling, scoped to the group the same way as its other
+// routes.
+func (g *RouteGroup) Options(pattern string, handler ContextHandlerFunc) {
+	g.addRoute(pattern, "OPTIONS", handler)
+}
+
+func (g *RouteGroup) addRoute(pattern, method string, handler ContextHandlerFunc) {
+	g.routes.AddHandlerFunc(g.prefix+pattern, method, g.wrapHandler(handler))
+}
+
+// wrapHandler chains the group's pre-hooks, handler and post-hooks into a
+// single ContextHandlerFunc that can be registered directly against the
+// shared route table. A non-nil pre-hook error short-circuits the chain
+// and is routed through g.router's ErrorHandler, mirroring
+// Router.ServeHTTP so a group behaves identically to a Router.
+func (g *RouteGroup) wrapHandler(handler ContextHandlerFunc) ContextHandlerFunc {
+	return func(ctx *Context) {
+		for _, hook := range g.preHooks {
+			if err := hook(ctx); err != nil {
+				g.router.handleError(ctx, err)
+				return
+			}
+		}
+		handler.ServeHTTP(ctx)
+		for _, hook := range g.postHooks {
+			hook(ctx)
+		}
+	}
+}
+
+// AddPreHook appends h to the list of hooks run, in order, after the
+// parent's hooks and before the matched handler for requests registered
+// on this group.
+func (g *RouteGroup) AddPreHook(h PreHookFunc) {
+	g.preHooks = append(g.preHooks, h)
+}
+
+// AddPostHook appends h to the list of hooks run, in order, after the
+// matched handler for requests registered on this group.
+func (g *RouteGroup) AddPostHook(h PostHookFunc) {
+	g.postHooks = append(g.postHooks, h)
+}
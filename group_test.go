@@ -0,0 +1,155 @@
+package mango
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGroupRegistersHandlerUnderPrefix(t *testing.T) {
+	want := "handlercalled"
+	callStack := ""
+	rtr := Router{}
+	rtr.routes = newMockRoutes()
+	grp := rtr.Group("/api")
+	grp.Get("/test", func(ctx *Context) {
+		callStack += "handlercalled"
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/test", nil)
+	rtr.ServeHTTP(w, req)
+
+	got := callStack
+	if got != want {
+		t.Errorf("Handler invocation = %q, want %q", got, want)
+	}
+}
+
+func TestGroupOptionsRegistersHandlerUnderPrefix(t *testing.T) {
+	want := "handlercalled"
+	callStack := ""
+	rtr := Router{}
+	rtr.routes = newMockRoutes()
+	grp := rtr.Group("/api")
+	grp.Options("/test", func(ctx *Context) {
+		callStack += "handlercalled"
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("OPTIONS", "/api/test", nil)
+	rtr.ServeHTTP(w, req)
+
+	got := callStack
+	if got != want {
+		t.Errorf("Handler invocation = %q, want %q", got, want)
+	}
+}
+
+func TestGroupHooksRunAfterParentHooks(t *testing.T) {
+	want := "parentgrouphandler"
+	callStack := ""
+
+	rtr := Router{}
+	rtr.routes = newMockRoutes()
+	rtr.AddPreHook(func(ctx *Context) error {
+		callStack += "parent"
+		return nil
+	})
+
+	grp := rtr.Group("/api")
+	grp.AddPreHook(func(ctx *Context) error {
+		callStack += "group"
+		return nil
+	})
+	grp.Get("/test", func(ctx *Context) {
+		callStack += "handler"
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/test", nil)
+	rtr.ServeHTTP(w, req)
+
+	got := callStack
+	if got != want {
+		t.Errorf("Hook order = %q, want %q", got, want)
+	}
+}
+
+func TestGroupPreHookErrorShortCircuitsHandler(t *testing.T) {
+	callStack := ""
+
+	rtr := Router{}
+	rtr.routes = newMockRoutes()
+	grp := rtr.Group("/api")
+	grp.AddPreHook(func(ctx *Context) error {
+		return ErrUnauthorized
+	})
+	grp.Get("/test", func(ctx *Context) {
+		callStack += "handlercalled"
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/test", nil)
+	rtr.ServeHTTP(w, req)
+
+	if callStack != "" {
+		t.Errorf("handler was called despite pre-hook error, callStack = %q", callStack)
+	}
+	want := http.StatusUnauthorized
+	got := w.Code
+	if got != want {
+		t.Errorf("Status = %d, want %d", got, want)
+	}
+}
+
+func TestNestedGroupRegistersHandlerUnderCombinedPrefix(t *testing.T) {
+	want := "handlercalled"
+	callStack := ""
+	rtr := Router{}
+	rtr.routes = newMockRoutes()
+	grp := rtr.Group("/api")
+	nested := grp.Group("/v2")
+	nested.Get("/test", func(ctx *Context) {
+		callStack += "handlercalled"
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v2/test", nil)
+	rtr.ServeHTTP(w, req)
+
+	got := callStack
+	if got != want {
+		t.Errorf("Handler invocation = %q, want %q", got, want)
+	}
+}
+
+func TestNestedGroupHooksRunAfterOuterGroupHooks(t *testing.T) {
+	want := "outernestedhandler"
+	callStack := ""
+
+	rtr := Router{}
+	rtr.routes = newMockRoutes()
+	grp := rtr.Group("/api")
+	grp.AddPreHook(func(ctx *Context) error {
+		callStack += "outer"
+		return nil
+	})
+	nested := grp.Group("/v2")
+	nested.AddPreHook(func(ctx *Context) error {
+		callStack += "nested"
+		return nil
+	})
+	nested.Get("/test", func(ctx *Context) {
+		callStack += "handler"
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v2/test", nil)
+	rtr.ServeHTTP(w, req)
+
+	got := callStack
+	if got != want {
+		t.Errorf("Hook order = %q, want %q", got, want)
+	}
+}
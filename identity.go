@@ -0,0 +1,8 @@
+package mango
+
+// Identity represents an authenticated principal attached to a Context by
+// a pre-hook that performs authentication.
+type Identity interface {
+	// ID returns a unique identifier for the authenticated principal.
+	ID() string
+}
@@ -0,0 +1,51 @@
+package mango
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// mediaType represents a single entry parsed from an Accept header, along
+// with its relative quality value.
+type mediaType struct {
+	mtype   string
+	subtype string
+	q       float64
+}
+
+// newMediaType parses a single media range, e.g. "application/json;q=0.8",
+// into a mediaType.
+func newMediaType(s string) (*mediaType, error) {
+	parts := strings.Split(s, ";")
+	types := strings.Split(parts[0], "/")
+	if len(types) != 2 || types[0] == "" || types[1] == "" {
+		return nil, fmt.Errorf("invalid media type: %q", s)
+	}
+
+	mt := &mediaType{mtype: types[0], subtype: types[1], q: 1.0}
+	for _, param := range parts[1:] {
+		if !strings.HasPrefix(param, "q=") {
+			continue
+		}
+		q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64)
+		if err == nil {
+			mt.q = q
+		}
+	}
+	return mt, nil
+}
+
+// String returns the media type in "type/subtype" form, without its
+// quality parameter.
+func (m mediaType) String() string {
+	return m.mtype + "/" + m.subtype
+}
+
+// mediaTypes implements sort.Interface, ordering entries by descending
+// quality value.
+type mediaTypes []mediaType
+
+func (m mediaTypes) Len() int           { return len(m) }
+func (m mediaTypes) Swap(i, j int)      { m[i], m[j] = m[j], m[i] }
+func (m mediaTypes) Less(i, j int) bool { return m[i].q > m[j].q }
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"crypto/subtle"
+
+	"github.com/codeafix/mango"
+)
+
+// basicAuthIdentity is the Identity assigned to Context by BasicAuth on a
+// successful authentication; its ID is the authenticated username.
+type basicAuthIdentity string
+
+// ID implements Identity.
+func (id basicAuthIdentity) ID() string {
+	return string(id)
+}
+
+// BasicAuth returns a PreHookFunc that authenticates a request using HTTP
+// Basic authentication against accounts, a map of username to password.
+// On success, it assigns a basicAuthIdentity to ctx.Identity, so
+// ctx.Authenticated() reports true. On failure it returns
+// mango.ErrUnauthorized, which the Router's ErrorHandler turns into a 401
+// response, complementing the existing Authenticated helper.
+func BasicAuth(accounts map[string]string) mango.PreHookFunc {
+	return func(ctx *mango.Context) error {
+		user, pass, ok := ctx.Request.BasicAuth()
+		if !ok {
+			return unauthorized(ctx)
+		}
+
+		want, known := accounts[user]
+		if !known || subtle.ConstantTimeCompare([]byte(pass), []byte(want)) != 1 {
+			return unauthorized(ctx)
+		}
+
+		ctx.Identity = basicAuthIdentity(user)
+		return nil
+	}
+}
+
+func unauthorized(ctx *mango.Context) error {
+	ctx.Writer.Header().Set("WWW-Authenticate", `Basic realm="restricted"`)
+	return mango.ErrUnauthorized
+}
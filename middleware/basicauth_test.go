@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/codeafix/mango"
+)
+
+func TestBasicAuthPopulatesIdentityOnSuccess(t *testing.T) {
+	rtr := mango.NewRouter()
+	rtr.AddPreHook(BasicAuth(map[string]string{"alice": "secret"}))
+
+	var gotID string
+	rtr.Get("/test", func(ctx *mango.Context) {
+		gotID = ctx.Identity.ID()
+		ctx.RespondWith("ok")
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.SetBasicAuth("alice", "secret")
+	rtr.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Status = %d, want %d", w.Code, http.StatusOK)
+	}
+	want := "alice"
+	if gotID != want {
+		t.Errorf("ctx.Identity.ID() = %q, want %q", gotID, want)
+	}
+}
+
+func TestBasicAuthReturns401OnWrongPassword(t *testing.T) {
+	rtr := mango.NewRouter()
+	rtr.AddPreHook(BasicAuth(map[string]string{"alice": "secret"}))
+	rtr.Get("/test", func(ctx *mango.Context) {
+		ctx.RespondWith("ok")
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.SetBasicAuth("alice", "wrong")
+	rtr.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+	if got := w.HeaderMap.Get("WWW-Authenticate"); got == "" {
+		t.Error("WWW-Authenticate header not set")
+	}
+}
+
+func TestBasicAuthReturns401WhenCredentialsMissing(t *testing.T) {
+	rtr := mango.NewRouter()
+	rtr.AddPreHook(BasicAuth(map[string]string{"alice": "secret"}))
+	rtr.Get("/test", func(ctx *mango.Context) {
+		ctx.RespondWith("ok")
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	rtr.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/codeafix/mango"
+)
+
+// CORSOptions configures CORS.
+type CORSOptions struct {
+	// AllowedOrigins lists origins permitted to make cross-origin
+	// requests. "*" allows any origin.
+	AllowedOrigins []string
+	// AllowedMethods lists methods permitted in a preflight request.
+	AllowedMethods []string
+	// AllowedHeaders lists headers permitted in a preflight request.
+	AllowedHeaders []string
+	// AllowCredentials sets Access-Control-Allow-Credentials when true.
+	AllowCredentials bool
+	// MaxAge sets Access-Control-Max-Age, in seconds, when greater than 0.
+	MaxAge int
+}
+
+// CORS returns a PreHookFunc that sets CORS response headers according to
+// opts, and answers preflight OPTIONS requests directly with a 204.
+//
+// Preflight requests must still be routed to something: register an
+// empty handler for the same pattern with Router.Options so the Router
+// doesn't respond 405 before this hook ever runs.
+func CORS(opts CORSOptions) mango.PreHookFunc {
+	allowAll := false
+	allowedOrigins := make(map[string]bool, len(opts.AllowedOrigins))
+	for _, o := range opts.AllowedOrigins {
+		if o == "*" {
+			allowAll = true
+		}
+		allowedOrigins[o] = true
+	}
+
+	return func(ctx *mango.Context) error {
+		origin := ctx.Request.Header.Get("Origin")
+		if origin == "" {
+			return nil
+		}
+		if !allowAll && !allowedOrigins[origin] {
+			return nil
+		}
+
+		h := ctx.Writer.Header()
+		if allowAll {
+			h.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			h.Set("Access-Control-Allow-Origin", origin)
+			h.Add("Vary", "Origin")
+		}
+		if opts.AllowCredentials {
+			h.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if ctx.Request.Method != http.MethodOptions {
+			return nil
+		}
+
+		if len(opts.AllowedMethods) > 0 {
+			h.Set("Access-Control-Allow-Methods", strings.Join(opts.AllowedMethods, ", "))
+		}
+		if len(opts.AllowedHeaders) > 0 {
+			h.Set("Access-Control-Allow-Headers", strings.Join(opts.AllowedHeaders, ", "))
+		}
+		if opts.MaxAge > 0 {
+			h.Set("Access-Control-Max-Age", strconv.Itoa(opts.MaxAge))
+		}
+		ctx.Respond().WithStatus(http.StatusNoContent)
+		return nil
+	}
+}
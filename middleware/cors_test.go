@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/codeafix/mango"
+)
+
+func TestCORSSetsAllowOriginForAllowedOrigin(t *testing.T) {
+	rtr := mango.NewRouter()
+	rtr.AddPreHook(CORS(CORSOptions{AllowedOrigins: []string{"https://example.com"}}))
+	rtr.Get("/test", func(ctx *mango.Context) {
+		ctx.RespondWith("ok")
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set("Origin", "https://example.com")
+	rtr.ServeHTTP(w, req)
+
+	want := "https://example.com"
+	if got := w.HeaderMap.Get("Access-Control-Allow-Origin"); got != want {
+		t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, want)
+	}
+}
+
+func TestCORSOmitsHeadersForDisallowedOrigin(t *testing.T) {
+	rtr := mango.NewRouter()
+	rtr.AddPreHook(CORS(CORSOptions{AllowedOrigins: []string{"https://example.com"}}))
+	rtr.Get("/test", func(ctx *mango.Context) {
+		ctx.RespondWith("ok")
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set("Origin", "https://evil.example")
+	rtr.ServeHTTP(w, req)
+
+	if got := w.HeaderMap.Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Access-Control-Allow-Origin = %q, want empty", got)
+	}
+}
+
+func TestCORSAnswersPreflightWithNoContent(t *testing.T) {
+	rtr := mango.NewRouter()
+	rtr.AddPreHook(CORS(CORSOptions{
+		AllowedOrigins: []string{"*"},
+		AllowedMethods: []string{"GET", "POST"},
+		AllowedHeaders: []string{"Content-Type"},
+		MaxAge:         600,
+	}))
+	rtr.Options("/test", func(ctx *mango.Context) {})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("OPTIONS", "/test", nil)
+	req.Header.Set("Origin", "https://example.com")
+	rtr.ServeHTTP(w, req)
+
+	if got := w.Code; got != http.StatusNoContent {
+		t.Errorf("Status = %d, want %d", got, http.StatusNoContent)
+	}
+	if got := w.HeaderMap.Get("Access-Control-Allow-Methods"); got != "GET, POST" {
+		t.Errorf("Access-Control-Allow-Methods = %q, want %q", got, "GET, POST")
+	}
+	if got := w.HeaderMap.Get("Access-Control-Max-Age"); got != "600" {
+		t.Errorf("Access-Control-Max-Age = %q, want %q", got, "600")
+	}
+}
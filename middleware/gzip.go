@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/codeafix/mango"
+)
+
+// skipCompression lists content types that are already compressed, or
+// otherwise not worth re-compressing.
+var skipCompression = map[string]bool{
+	"image/png":         true,
+	"image/jpeg":        true,
+	"image/gif":         true,
+	"image/webp":        true,
+	"video/mp4":         true,
+	"application/zip":   true,
+	"application/gzip":  true,
+	"font/woff2":        true,
+	"text/event-stream": true,
+}
+
+// Gzip returns a Middleware that compresses the response body with gzip
+// at the given compression level (see compress/gzip's level constants)
+// when the request's Accept-Encoding header allows it, setting
+// Content-Encoding and Vary accordingly. It skips requests that don't
+// accept gzip and responses whose Content-Type is in skipCompression.
+//
+// level is validated once here with gzip.NewWriterLevel, falling back to
+// gzip.DefaultCompression if it's out of range, so a bad level can never
+// leave a response with Content-Encoding: gzip set but an uncompressed
+// body.
+//
+// Gzip is a Middleware, not a PreHookFunc/PostHookFunc: the gzip writer
+// must be closed once the handler, its post-hooks and response writing
+// have all finished, which only a Middleware registered with Router.Use
+// can guarantee.
+func Gzip(level int) mango.Middleware {
+	if _, err := gzip.NewWriterLevel(io.Discard, level); err != nil {
+		level = gzip.DefaultCompression
+	}
+
+	return func(next mango.ContextHandlerFunc) mango.ContextHandlerFunc {
+		return func(ctx *mango.Context) {
+			if !acceptsGzip(ctx.Request) {
+				next.ServeHTTP(ctx)
+				return
+			}
+
+			gzw := &gzipResponseWriter{ResponseWriter: ctx.Writer, level: level}
+			ctx.Writer = gzw
+			next.ServeHTTP(ctx)
+			gzw.Close()
+		}
+	}
+}
+
+func acceptsGzip(req *http.Request) bool {
+	for _, enc := range strings.Split(req.Header.Get("Accept-Encoding"), ",") {
+		if strings.TrimSpace(enc) == "gzip" {
+			return true
+		}
+	}
+	return false
+}
+
+// gzipResponseWriter defers deciding whether to compress until it knows
+// there is actually a body: WriteHeader alone only finalizes the response
+// immediately for 204/304, which by definition carry no body (RFC 7230);
+// otherwise finalization waits for the first Write, or for Close if the
+// handler never writes one. It also forwards Flush and Hijack to the
+// underlying ResponseWriter, so wrapping it doesn't break streaming
+// responses such as Context.SSE.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	level         int
+	gz            *gzip.Writer
+	statusCode    int
+	headerWritten bool
+}
+
+func (w *gzipResponseWriter) WriteHeader(code int) {
+	if w.headerWritten {
+		return
+	}
+	w.statusCode = code
+	if code == http.StatusNoContent || code == http.StatusNotModified {
+		w.finalize(code, false)
+	}
+}
+
+func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	if !w.headerWritten {
+		w.finalize(w.effectiveStatus(), len(b) > 0)
+	}
+	if w.gz != nil {
+		return w.gz.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *gzipResponseWriter) effectiveStatus() int {
+	if w.statusCode == 0 {
+		return http.StatusOK
+	}
+	return w.statusCode
+}
+
+// finalize enables compression, if compress is true and the response's
+// Content-Type isn't in skipCompression, then writes code to the
+// underlying ResponseWriter.
+func (w *gzipResponseWriter) finalize(code int, compress bool) {
+	w.headerWritten = true
+	if compress && code != http.StatusNoContent && code != http.StatusNotModified {
+		ct := strings.SplitN(w.Header().Get("Content-Type"), ";", 2)[0]
+		if !skipCompression[ct] {
+			w.Header().Set("Content-Encoding", "gzip")
+			w.Header().Add("Vary", "Accept-Encoding")
+			w.Header().Del("Content-Length")
+			w.gz, _ = gzip.NewWriterLevel(w.ResponseWriter, w.level)
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Close flushes and closes the underlying gzip writer, if one was opened,
+// finalizing the response header first if the handler never wrote a body
+// (for example a 200 with no payload).
+func (w *gzipResponseWriter) Close() error {
+	if !w.headerWritten {
+		w.finalize(w.effectiveStatus(), false)
+	}
+	if w.gz != nil {
+		return w.gz.Close()
+	}
+	return nil
+}
+
+// Flush flushes any buffered gzip output, then flushes the underlying
+// ResponseWriter if it supports http.Flusher.
+func (w *gzipResponseWriter) Flush() {
+	if w.gz != nil {
+		w.gz.Flush()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker by delegating to the underlying
+// ResponseWriter, if it supports it.
+func (w *gzipResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("gzipResponseWriter: underlying ResponseWriter does not support http.Hijacker")
+	}
+	return hj.Hijack()
+}
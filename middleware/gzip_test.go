@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/codeafix/mango"
+)
+
+func TestGzipCompressesWhenAcceptEncodingAllows(t *testing.T) {
+	rtr := mango.NewRouter()
+	rtr.Use(Gzip(gzip.DefaultCompression))
+	rtr.Get("/test", func(ctx *mango.Context) {
+		ctx.RespondWith(strings.Repeat("hello world ", 50))
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rtr.ServeHTTP(w, req)
+
+	if got := w.HeaderMap.Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("Content-Encoding = %q, want %q", got, "gzip")
+	}
+
+	gz, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatalf("response body is not valid gzip: %v", err)
+	}
+	defer gz.Close()
+	body, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatalf("unable to read gzip body: %v", err)
+	}
+
+	want := strings.Repeat("hello world ", 50)
+	if string(body) != want {
+		t.Errorf("Body = %q, want %q", body, want)
+	}
+}
+
+func TestGzipSkipsWhenAcceptEncodingDisallows(t *testing.T) {
+	rtr := mango.NewRouter()
+	rtr.Use(Gzip(gzip.DefaultCompression))
+	rtr.Get("/test", func(ctx *mango.Context) {
+		ctx.RespondWith("plain text")
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	rtr.ServeHTTP(w, req)
+
+	if got := w.HeaderMap.Get("Content-Encoding"); got != "" {
+		t.Errorf("Content-Encoding = %q, want empty", got)
+	}
+	want := "plain text"
+	if got := w.Body.String(); got != want {
+		t.Errorf("Body = %q, want %q", got, want)
+	}
+}
+
+func TestGzipSkipsResponseWithNoContentStatus(t *testing.T) {
+	rtr := mango.NewRouter()
+	rtr.Use(Gzip(gzip.DefaultCompression))
+	rtr.Get("/test", func(ctx *mango.Context) {
+		ctx.Respond().WithStatus(http.StatusNoContent)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rtr.ServeHTTP(w, req)
+
+	if got := w.Code; got != http.StatusNoContent {
+		t.Errorf("Status = %d, want %d", got, http.StatusNoContent)
+	}
+	if got := w.HeaderMap.Get("Content-Encoding"); got != "" {
+		t.Errorf("Content-Encoding = %q, want empty", got)
+	}
+	if got := w.Body.Len(); got != 0 {
+		t.Errorf("Body length = %d, want 0", got)
+	}
+}
+
+func TestGzipResponseWriterImplementsFlusher(t *testing.T) {
+	rtr := mango.NewRouter()
+	rtr.Use(Gzip(gzip.DefaultCompression))
+	flushable := false
+	rtr.Get("/test", func(ctx *mango.Context) {
+		ctx.Writer.Write([]byte("chunk"))
+		_, flushable = ctx.Writer.(http.Flusher)
+		if flushable {
+			ctx.Writer.(http.Flusher).Flush()
+		}
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rtr.ServeHTTP(w, req)
+
+	if !flushable {
+		t.Fatal("gzipResponseWriter does not implement http.Flusher")
+	}
+	if !w.Flushed {
+		t.Error("underlying ResponseWriter was never flushed")
+	}
+}
+
+func TestGzipSkipsAlreadyCompressedContentTypes(t *testing.T) {
+	rtr := mango.NewRouter()
+	rtr.Use(Gzip(gzip.DefaultCompression))
+	rtr.Get("/test", func(ctx *mango.Context) {
+		ctx.Respond().WithContentType("image/png")
+		ctx.RespondWith("not actually a png")
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rtr.ServeHTTP(w, req)
+
+	if got := w.HeaderMap.Get("Content-Encoding"); got != "" {
+		t.Errorf("Content-Encoding = %q, want empty", got)
+	}
+	want := "not actually a png"
+	if got := w.Body.String(); got != want {
+		t.Errorf("Body = %q, want %q", got, want)
+	}
+}
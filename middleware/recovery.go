@@ -0,0 +1,40 @@
+// Package middleware provides a small collection of commonly needed
+// Router middleware and hooks: panic recovery, request ids, response
+// compression, CORS and basic authentication.
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/codeafix/mango"
+)
+
+// Recovery returns a Middleware that recovers a panic raised anywhere
+// within the wrapped handler's execution (including its post-hooks and
+// response writing), logs the panic value and a stack trace, and writes a
+// 500 response through Context.ErrorWith, with the same "Sorry, something
+// went wrong." detail the Router sends for encoder failures. Unlike the
+// Router's own error responses, its Problem carries no Instance.
+//
+// Recovery is a Middleware, not a PreHookFunc/PostHookFunc, registered
+// with Router.Use: recover only works from a defer in a function still on
+// the stack when the panic happens, which a pre-hook or post-hook is not.
+func Recovery() mango.Middleware {
+	return func(next mango.ContextHandlerFunc) mango.ContextHandlerFunc {
+		return func(ctx *mango.Context) {
+			defer func() {
+				if rec := recover(); rec != nil {
+					log.Printf("mango: recovered from panic: %v\n%s", rec, debug.Stack())
+					ctx.ErrorWith(&mango.Problem{
+						Title:  http.StatusText(http.StatusInternalServerError),
+						Status: http.StatusInternalServerError,
+						Detail: "Sorry, something went wrong.",
+					})
+				}
+			}()
+			next.ServeHTTP(ctx)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/codeafix/mango"
+)
+
+func TestRecoveryReturns500OnPanic(t *testing.T) {
+	rtr := mango.NewRouter()
+	rtr.Use(Recovery())
+	rtr.Get("/test", func(ctx *mango.Context) {
+		panic("boom")
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	rtr.ServeHTTP(w, req)
+
+	want := http.StatusInternalServerError
+	got := w.Code
+	if got != want {
+		t.Errorf("Status = %d, want %d", got, want)
+	}
+}
+
+func TestRecoveryDoesNotInterfereWithSuccessfulRequests(t *testing.T) {
+	rtr := mango.NewRouter()
+	rtr.Use(Recovery())
+	rtr.Get("/test", func(ctx *mango.Context) {
+		ctx.RespondWith("ok")
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	rtr.ServeHTTP(w, req)
+
+	want := "ok"
+	got := w.Body.String()
+	if got != want {
+		t.Errorf("Body = %q, want %q", got, want)
+	}
+}
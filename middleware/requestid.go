@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/codeafix/mango"
+)
+
+// RequestID returns a PreHookFunc that assigns ctx.RequestID from the
+// request header named headerName if present, or generates a new random
+// id otherwise, and propagates it onto the response via the same header
+// so callers and downstream logs can correlate a request end-to-end.
+func RequestID(headerName string) mango.PreHookFunc {
+	return func(ctx *mango.Context) error {
+		id := ctx.Request.Header.Get(headerName)
+		if id == "" {
+			id = newRequestID()
+		}
+		ctx.RequestID = id
+		ctx.Writer.Header().Set(headerName, id)
+		return nil
+	}
+}
+
+// newRequestID generates a random 16-byte id, hex-encoded.
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/codeafix/mango"
+)
+
+func TestRequestIDGeneratesIDWhenHeaderAbsent(t *testing.T) {
+	rtr := mango.NewRouter()
+	rtr.AddPreHook(RequestID("X-Request-ID"))
+
+	var gotID string
+	rtr.Get("/test", func(ctx *mango.Context) {
+		gotID = ctx.RequestID
+		ctx.RespondWith("ok")
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	rtr.ServeHTTP(w, req)
+
+	if gotID == "" {
+		t.Fatal("ctx.RequestID was not populated")
+	}
+	if got := w.HeaderMap.Get("X-Request-ID"); got != gotID {
+		t.Errorf("X-Request-ID header = %q, want %q", got, gotID)
+	}
+}
+
+func TestRequestIDPropagatesIncomingHeader(t *testing.T) {
+	rtr := mango.NewRouter()
+	rtr.AddPreHook(RequestID("X-Request-ID"))
+
+	var gotID string
+	rtr.Get("/test", func(ctx *mango.Context) {
+		gotID = ctx.RequestID
+		ctx.RespondWith("ok")
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set("X-Request-ID", "incoming-id")
+	rtr.ServeHTTP(w, req)
+
+	want := "incoming-id"
+	if gotID != want {
+		t.Errorf("ctx.RequestID = %q, want %q", gotID, want)
+	}
+	if got := w.HeaderMap.Get("X-Request-ID"); got != want {
+		t.Errorf("X-Request-ID header = %q, want %q", got, want)
+	}
+}
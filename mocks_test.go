@@ -0,0 +1,62 @@
+package mango
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// testFunc is a handler used across tests where the behaviour of the
+// handler itself doesn't matter, only that it was registered/invoked.
+func testFunc(ctx *Context) {}
+
+// extractFnName returns the unqualified function name backing h, so tests
+// can assert on which handler was registered without comparing funcs
+// directly (Go funcs aren't comparable).
+func extractFnName(h ContextHandlerFunc) string {
+	name := runtime.FuncForPC(reflect.ValueOf(h).Pointer()).Name()
+	parts := strings.Split(name, ".")
+	return parts[len(parts)-1]
+}
+
+// mockEncoder only knows how to encode strings, so tests can exercise both
+// the success and failure paths of the encoding pipeline.
+type mockEncoder struct {
+	w io.Writer
+}
+
+func (e *mockEncoder) Encode(v interface{}) error {
+	s, ok := v.(string)
+	if !ok {
+		return errors.New("mockEncoder: unable to encode value")
+	}
+	_, err := fmt.Fprint(e.w, s)
+	return err
+}
+
+// mockEncoderEngine only supports the "test/test" media type, returning an
+// error for anything else so router tests can exercise unsupported Accept
+// headers.
+type mockEncoderEngine struct{}
+
+func (m *mockEncoderEngine) RegisterEncoder(mediaType string, maker EncoderMaker) {}
+
+func (m *mockEncoderEngine) RegisterDecoder(mediaType string, maker DecoderMaker) {}
+
+func (m *mockEncoderEngine) GetEncoder(w io.Writer, mediaType string) (Encoder, error) {
+	if mediaType != "test/test" {
+		return nil, fmt.Errorf("mockEncoderEngine: unsupported media type %q", mediaType)
+	}
+	return &mockEncoder{w: w}, nil
+}
+
+func (m *mockEncoderEngine) GetDecoder(r io.Reader, mediaType string) (Decoder, error) {
+	return nil, fmt.Errorf("mockEncoderEngine: unsupported media type %q", mediaType)
+}
+
+func (m *mockEncoderEngine) DefaultMediaType() string {
+	return "test/test"
+}
@@ -0,0 +1,44 @@
+package mango
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+)
+
+// Problem is a machine-readable error body modeled on RFC 7807
+// (https://tools.ietf.org/html/rfc7807).
+type Problem struct {
+	XMLName  xml.Name `json:"-" xml:"problem"`
+	Type     string   `json:"type,omitempty" xml:"type,omitempty"`
+	Title    string   `json:"title,omitempty" xml:"title,omitempty"`
+	Status   int      `json:"status,omitempty" xml:"status,omitempty"`
+	Detail   string   `json:"detail,omitempty" xml:"detail,omitempty"`
+	Instance string   `json:"instance,omitempty" xml:"instance,omitempty"`
+}
+
+// negotiateProblem attempts to serialize problem using an encoder
+// registered against engine that matches req's Accept header, falling
+// back to engine's DefaultMediaType for "*/*". It returns the chosen
+// media type and serialized body, or ok=false if nothing matched.
+func negotiateProblem(engine EncoderEngine, req *http.Request, problem *Problem) (mediaType string, body []byte, ok bool) {
+	if engine == nil || req == nil {
+		return "", nil, false
+	}
+
+	for _, mt := range acceptHeaderMediaTypes(req) {
+		if mt == "*/*" {
+			mt = engine.DefaultMediaType()
+		}
+		var buf bytes.Buffer
+		encoder, err := engine.GetEncoder(&buf, mt)
+		if err != nil {
+			continue
+		}
+		if err := encoder.Encode(problem); err != nil {
+			continue
+		}
+		return mt, buf.Bytes(), true
+	}
+	return "", nil, false
+}
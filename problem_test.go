@@ -0,0 +1,79 @@
+package mango
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendErrorSerializesProblemAsJSONWhenAcceptMatches(t *testing.T) {
+	rtr := NewRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/missing", nil)
+	req.Header.Set("Accept", "application/json")
+	rtr.ServeHTTP(w, req)
+
+	wantContentType := "application/json"
+	gotContentType := w.HeaderMap.Get("Content-Type")
+	if gotContentType != wantContentType {
+		t.Errorf("Content-Type = %q, want %q", gotContentType, wantContentType)
+	}
+
+	var problem Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("Unable to decode body as Problem: %v", err)
+	}
+	if problem.Status != http.StatusNotFound {
+		t.Errorf("Problem.Status = %d, want %d", problem.Status, http.StatusNotFound)
+	}
+	if problem.Instance != "/missing" {
+		t.Errorf("Problem.Instance = %q, want %q", problem.Instance, "/missing")
+	}
+}
+
+func TestSendErrorFallsBackToPlainTextWhenNoEncoderMatches(t *testing.T) {
+	rtr := NewRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/missing", nil)
+	req.Header.Set("Accept", "text/plain")
+	rtr.ServeHTTP(w, req)
+
+	want := "Not Found"
+	got := w.Body.String()
+	if got != want {
+		t.Errorf("Body = %q, want %q", got, want)
+	}
+	wantContentType := "text/plain; charset=utf-8"
+	gotContentType := w.HeaderMap.Get("Content-Type")
+	if gotContentType != wantContentType {
+		t.Errorf("Content-Type = %q, want %q", gotContentType, wantContentType)
+	}
+}
+
+func TestErrorWithSerializesProblemAccordingToAccept(t *testing.T) {
+	rtr := NewRouter()
+	rtr.GetR("/test", func(ctx *Context) error {
+		ctx.ErrorWith(&Problem{Title: "Teapot", Status: http.StatusTeapot, Detail: "no coffee here"})
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set("Accept", "application/json")
+	rtr.ServeHTTP(w, req)
+
+	if w.Code != http.StatusTeapot {
+		t.Errorf("Status = %d, want %d", w.Code, http.StatusTeapot)
+	}
+
+	var problem Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("Unable to decode body as Problem: %v", err)
+	}
+	if problem.Detail != "no coffee here" {
+		t.Errorf("Problem.Detail = %q, want %q", problem.Detail, "no coffee here")
+	}
+}
@@ -0,0 +1,122 @@
+package mango
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGetRAddsHandlerToRoutes(t *testing.T) {
+	rtr := Router{}
+	rtr.routes = newMockRoutes()
+	rtr.GetR("/test", func(ctx *Context) error { return nil })
+
+	handlers, _, ok := rtr.routes.HandlerFuncs("/test")
+	if !ok {
+		t.Fatalf("Handler not added")
+	}
+	if _, ok := handlers["GET"]; !ok {
+		t.Errorf("Handler not registered against GET")
+	}
+}
+
+func TestGetRHandlerReturningNilErrorUsesNormalResponse(t *testing.T) {
+	rtr := Router{}
+	rtr.routes = newMockRoutes()
+	rtr.GetR("/test", func(ctx *Context) error {
+		ctx.RespondWith("payload")
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	rtr.ServeHTTP(w, req)
+
+	want := "payload"
+	got := w.Body.String()
+	if got != want {
+		t.Errorf("Response = %q, want %q", got, want)
+	}
+}
+
+func TestGetRHandlerReturningHTTPErrorUsesItsCodeAndMessage(t *testing.T) {
+	rtr := Router{}
+	rtr.routes = newMockRoutes()
+	rtr.GetR("/test", func(ctx *Context) error {
+		return ErrNotFound
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	rtr.ServeHTTP(w, req)
+
+	wantCode := http.StatusNotFound
+	if w.Code != wantCode {
+		t.Errorf("Status = %d, want %d", w.Code, wantCode)
+	}
+	wantMsg := ErrNotFound.Message
+	if got := strings.TrimSpace(w.Body.String()); got != wantMsg {
+		t.Errorf("Body = %q, want %q", got, wantMsg)
+	}
+}
+
+func TestGetRHandlerReturningOpaqueErrorUses500(t *testing.T) {
+	rtr := Router{}
+	rtr.routes = newMockRoutes()
+	rtr.GetR("/test", func(ctx *Context) error {
+		return errors.New("boom")
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	rtr.ServeHTTP(w, req)
+
+	wantCode := http.StatusInternalServerError
+	if w.Code != wantCode {
+		t.Errorf("Status = %d, want %d", w.Code, wantCode)
+	}
+}
+
+func TestPreHookErrorShortCircuitsHandler(t *testing.T) {
+	callStack := ""
+	rtr := Router{}
+	rtr.routes = newMockRoutes()
+	rtr.AddPreHook(func(ctx *Context) error {
+		return ErrUnauthorized
+	})
+	rtr.Get("/test", func(ctx *Context) {
+		callStack += "handler"
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	rtr.ServeHTTP(w, req)
+
+	if callStack != "" {
+		t.Errorf("Handler was called despite pre-hook error")
+	}
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestCustomErrorHandlerIsUsed(t *testing.T) {
+	rtr := Router{}
+	rtr.routes = newMockRoutes()
+	rtr.ErrorHandler = func(ctx *Context, err error) {
+		ctx.Error("custom: "+err.Error(), http.StatusTeapot)
+	}
+	rtr.GetR("/test", func(ctx *Context) error {
+		return errors.New("boom")
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	rtr.ServeHTTP(w, req)
+
+	if w.Code != http.StatusTeapot {
+		t.Errorf("Status = %d, want %d", w.Code, http.StatusTeapot)
+	}
+}
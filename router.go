@@ -0,0 +1,268 @@
+package mango
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// PreHookFunc is called before the matched handler for a request. It may
+// inspect or mutate the Context, for example to perform authentication.
+type PreHookFunc func(*Context) error
+
+// PostHookFunc is called after the matched handler for a request, once a
+// response has been prepared.
+type PostHookFunc func(*Context) error
+
+// Middleware wraps a ContextHandlerFunc to add behaviour around its
+// execution, such as recovering panics or compressing the response body.
+// Unlike a PreHookFunc or PostHookFunc, a Middleware's code runs both
+// before and after the handler it wraps (and, crucially, stays on the
+// stack while it runs), so it can recover from a panic in the handler or
+// finalize a wrapped ResponseWriter once the handler and all post-hooks
+// are done.
+type Middleware func(ContextHandlerFunc) ContextHandlerFunc
+
+// Router dispatches incoming requests to registered handlers, running any
+// configured pre and post hooks around them.
+type Router struct {
+	routes        routeTable
+	preHooks      []PreHookFunc
+	postHooks     []PostHookFunc
+	middleware    []Middleware
+	encoderEngine EncoderEngine
+	// ErrorHandler translates an error returned from a ContextReturnHandlerFunc,
+	// or from a pre-hook, into a response. If nil, DefaultErrorHandler is used.
+	ErrorHandler ErrorHandler
+}
+
+// NewRouter creates a Router ready for use, with its route table and
+// encoderEngine initialised to their default implementations.
+func NewRouter() *Router {
+	return &Router{
+		routes:        newTree(),
+		encoderEngine: newEncoderEngine(),
+	}
+}
+
+// Get registers handler to be called for GET requests matching pattern.
+func (rtr *Router) Get(pattern string, handler ContextHandlerFunc) {
+	rtr.addRoute(pattern, "GET", handler)
+}
+
+// Post registers handler to be called for POST requests matching pattern.
+func (rtr *Router) Post(pattern string, handler ContextHandlerFunc) {
+	rtr.addRoute(pattern, "POST", handler)
+}
+
+// Put registers handler to be called for PUT requests matching pattern.
+func (rtr *Router) Put(pattern string, handler ContextHandlerFunc) {
+	rtr.addRoute(pattern, "PUT", handler)
+}
+
+// Patch registers handler to be called for PATCH requests matching pattern.
+func (rtr *Router) Patch(pattern string, handler ContextHandlerFunc) {
+	rtr.addRoute(pattern, "PATCH", handler)
+}
+
+// Del registers handler to be called for DELETE requests matching pattern.
+func (rtr *Router) Del(pattern string, handler ContextHandlerFunc) {
+	rtr.addRoute(pattern, "DELETE", handler)
+}
+
+// Options registers handler to be called for OPTIONS requests matching
+// pattern. This is most useful for middleware such as CORS preflight
+// handling, which needs to respond to OPTIONS without that being a
+// handler's own concern.
+func (rtr *Router) Options(pattern string, handler ContextHandlerFunc) {
+	rtr.addRoute(pattern, "OPTIONS", handler)
+}
+
+func (rtr *Router) addRoute(pattern, method string, handler ContextHandlerFunc) {
+	if rtr.routes == nil {
+		rtr.routes = newTree()
+	}
+	rtr.routes.AddHandlerFunc(pattern, method, handler)
+}
+
+// GetR registers handler to be called for GET requests matching pattern.
+// Unlike Get, handler may return an error, which is translated into a
+// response by the Router's ErrorHandler.
+func (rtr *Router) GetR(pattern string, handler ContextReturnHandlerFunc) {
+	rtr.addRoute(pattern, "GET", rtr.wrapReturnHandler(handler))
+}
+
+// PostR registers handler to be called for POST requests matching pattern.
+// Unlike Post, handler may return an error, which is translated into a
+// response by the Router's ErrorHandler.
+func (rtr *Router) PostR(pattern string, handler ContextReturnHandlerFunc) {
+	rtr.addRoute(pattern, "POST", rtr.wrapReturnHandler(handler))
+}
+
+// PutR registers handler to be called for PUT requests matching pattern.
+// Unlike Put, handler may return an error, which is translated into a
+// response by the Router's ErrorHandler.
+func (rtr *Router) PutR(pattern string, handler ContextReturnHandlerFunc) {
+	rtr.addRoute(pattern, "PUT", rtr.wrapReturnHandler(handler))
+}
+
+// PatchR registers handler to be called for PATCH requests matching
+// pattern. Unlike Patch, handler may return an error, which is translated
+// into a response by the Router's ErrorHandler.
+func (rtr *Router) PatchR(pattern string, handler ContextReturnHandlerFunc) {
+	rtr.addRoute(pattern, "PATCH", rtr.wrapReturnHandler(handler))
+}
+
+// DelR registers handler to be called for DELETE requests matching
+// pattern. Unlike Del, handler may return an error, which is translated
+// into a response by the Router's ErrorHandler.
+func (rtr *Router) DelR(pattern string, handler ContextReturnHandlerFunc) {
+	rtr.addRoute(pattern, "DELETE", rtr.wrapReturnHandler(handler))
+}
+
+// wrapReturnHandler adapts a ContextReturnHandlerFunc into a
+// ContextHandlerFunc, routing any returned error through the Router's
+// ErrorHandler.
+func (rtr *Router) wrapReturnHandler(handler ContextReturnHandlerFunc) ContextHandlerFunc {
+	return func(ctx *Context) {
+		if err := handler(ctx); err != nil {
+			rtr.handleError(ctx, err)
+		}
+	}
+}
+
+// AddPreHook appends h to the list of hooks run, in order, before the
+// matched handler for every request.
+func (rtr *Router) AddPreHook(h PreHookFunc) {
+	rtr.preHooks = append(rtr.preHooks, h)
+}
+
+// AddPostHook appends h to the list of hooks run, in order, after the
+// matched handler for every request.
+func (rtr *Router) AddPostHook(h PostHookFunc) {
+	rtr.postHooks = append(rtr.postHooks, h)
+}
+
+// Use appends mw to the chain of middleware wrapped around every request's
+// matched handler, its post-hooks and the writing of its response.
+// Middleware registered first wraps outermost, so it is the first to run
+// on the way in and the last to run on the way out. On the way in this
+// matches AddPreHook's ordering; post-hooks, by contrast, run in
+// registration order. Use Middleware, rather than a pre/post hook,
+// when behaviour genuinely needs to wrap the handler's execution, such as
+// recovering a panic or finalizing a wrapped ResponseWriter.
+func (rtr *Router) Use(mw Middleware) {
+	rtr.middleware = append(rtr.middleware, mw)
+}
+
+// sendError writes msg as the response body with the supplied status
+// code. If req is non-nil, the body is serialized as a Problem through
+// the encoderEngine using req's Accept header; otherwise, or if no
+// encoder matches, it falls back to a plain text body of msg.
+func (rtr *Router) sendError(w http.ResponseWriter, req *http.Request, msg string, code int) {
+	problem := &Problem{Title: http.StatusText(code), Status: code, Detail: msg}
+	if req != nil && req.URL != nil {
+		problem.Instance = req.URL.Path
+	}
+
+	if mt, body, ok := negotiateProblem(rtr.encoderEngine, req, problem); ok {
+		w.Header().Set("Content-Type", mt)
+		w.WriteHeader(code)
+		w.Write(body)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(code)
+	fmt.Fprint(w, msg)
+}
+
+// ServeHTTP implements http.Handler. It resolves the request path and
+// method to a registered handler, runs the configured pre-hooks, the
+// handler, and the post-hooks, then writes whatever response the handler
+// prepared on the Context.
+func (rtr *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	handlers, params, ok := rtr.routes.HandlerFuncs(req.URL.Path)
+	if !ok {
+		rtr.sendError(w, req, "Not Found", http.StatusNotFound)
+		return
+	}
+
+	handler, ok := handlers[req.Method]
+	if !ok {
+		rtr.sendError(w, req, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx := &Context{
+		Request:       req,
+		Writer:        w,
+		RouteParams:   params,
+		encoderEngine: rtr.encoderEngine,
+	}
+
+	for _, hook := range rtr.preHooks {
+		if err := hook(ctx); err != nil {
+			rtr.handleError(ctx, err)
+			return
+		}
+	}
+
+	wrapped := ContextHandlerFunc(func(ctx *Context) {
+		handler.ServeHTTP(ctx)
+		for _, hook := range rtr.postHooks {
+			hook(ctx)
+		}
+		rtr.writeResponse(ctx.Writer, ctx)
+	})
+	for i := len(rtr.middleware) - 1; i >= 0; i-- {
+		wrapped = rtr.middleware[i](wrapped)
+	}
+	wrapped.ServeHTTP(ctx)
+}
+
+// handleError routes err through the Router's ErrorHandler, falling back
+// to DefaultErrorHandler if none has been configured.
+func (rtr *Router) handleError(ctx *Context, err error) {
+	h := rtr.ErrorHandler
+	if h == nil {
+		h = DefaultErrorHandler
+	}
+	h(ctx, err)
+}
+
+// writeResponse writes whatever response a handler prepared on ctx: a
+// serialized model, a raw payload, or just a status code. If nothing was
+// prepared the response defaults to 200 OK.
+func (rtr *Router) writeResponse(w http.ResponseWriter, ctx *Context) {
+	if ctx.streaming {
+		return
+	}
+
+	if ctx.model != nil {
+		encoder, mt, err := ctx.GetEncoder()
+		if err != nil {
+			rtr.sendError(w, ctx.Request, fmt.Sprintf("Unable to encode to requested acceptable formats: %q", mt), http.StatusNotAcceptable)
+			return
+		}
+		if ctx.status != 0 {
+			w.WriteHeader(ctx.status)
+		}
+		if err := encoder.Encode(ctx.model); err != nil {
+			rtr.sendError(w, ctx.Request, "Sorry, something went wrong.", http.StatusInternalServerError)
+			return
+		}
+		return
+	}
+
+	if ctx.payload != nil {
+		if ctx.status != 0 {
+			w.WriteHeader(ctx.status)
+		}
+		w.Write(ctx.payload)
+		return
+	}
+
+	if ctx.status != 0 {
+		w.WriteHeader(ctx.status)
+	}
+}
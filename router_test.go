@@ -114,7 +114,7 @@ func TestDeleteAddsHandlerToRoutes(t *testing.T) {
 func TestSendErrorUsesSuppliedStatusCode(t *testing.T) {
 	r := Router{}
 	w := httptest.NewRecorder()
-	r.sendError(w, "an error string", 404)
+	r.sendError(w, nil, "an error string", 404)
 	want := 404
 	got := w.Code
 	if got != want {
@@ -125,7 +125,7 @@ func TestSendErrorUsesSuppliedStatusCode(t *testing.T) {
 func TestSendErrorUsesSuppliedErrorMessage(t *testing.T) {
 	r := Router{}
 	w := httptest.NewRecorder()
-	r.sendError(w, "an error string", 404)
+	r.sendError(w, nil, "an error string", 404)
 	want := "an error string"
 	got := w.Body.String()
 	if got != want {
@@ -136,7 +136,7 @@ func TestSendErrorUsesSuppliedErrorMessage(t *testing.T) {
 func TestSendErrorSetsContentTypeToTextPlain(t *testing.T) {
 	r := Router{}
 	w := httptest.NewRecorder()
-	r.sendError(w, "an error string", 404)
+	r.sendError(w, nil, "an error string", 404)
 	want := "text/plain; charset=utf-8"
 	got := w.HeaderMap.Get("Content-Type")
 	if got != want {
@@ -494,6 +494,71 @@ func TestResponseMessageWhenErrorEncodingPayload(t *testing.T) {
 	}
 }
 
+func TestUseWrapsMiddlewareInRegistrationOrder(t *testing.T) {
+	want := "mw1-before mw2-before handler mw2-after mw1-after"
+	callStack := ""
+	mw1 := func(next ContextHandlerFunc) ContextHandlerFunc {
+		return func(ctx *Context) {
+			callStack += "mw1-before "
+			next.ServeHTTP(ctx)
+			callStack += "mw1-after"
+		}
+	}
+	mw2 := func(next ContextHandlerFunc) ContextHandlerFunc {
+		return func(ctx *Context) {
+			callStack += "mw2-before "
+			next.ServeHTTP(ctx)
+			callStack += "mw2-after "
+		}
+	}
+
+	rtr := Router{}
+	rtr.routes = newMockRoutes()
+	rtr.Use(mw1)
+	rtr.Use(mw2)
+	rtr.Get("/test", func(ctx *Context) {
+		callStack += "handler "
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	rtr.ServeHTTP(w, req)
+
+	got := callStack
+	if got != want {
+		t.Errorf("callStack = %q, want %q", got, want)
+	}
+}
+
+func TestUseMiddlewareRunsAfterPostHooks(t *testing.T) {
+	want := "handler posthook middleware-after"
+	callStack := ""
+	rtr := Router{}
+	rtr.routes = newMockRoutes()
+	rtr.AddPostHook(func(ctx *Context) error {
+		callStack += "posthook "
+		return nil
+	})
+	rtr.Use(func(next ContextHandlerFunc) ContextHandlerFunc {
+		return func(ctx *Context) {
+			next.ServeHTTP(ctx)
+			callStack += "middleware-after"
+		}
+	})
+	rtr.Get("/test", func(ctx *Context) {
+		callStack += "handler "
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	rtr.ServeHTTP(w, req)
+
+	got := callStack
+	if got != want {
+		t.Errorf("callStack = %q, want %q", got, want)
+	}
+}
+
 func TestNewRouterSetsRoutes(t *testing.T) {
 	want := reflect.TypeOf(&tree{}).String()
 	r := NewRouter()
@@ -527,4 +592,4 @@ func TestNewRouterInitialisesEncoderEngineWithDefaultMediaType(t *testing.T) {
 	if got != want {
 		t.Errorf("EncoderEngine.DefaultMediaType = %q, want %q", got, want)
 	}
-}
\ No newline at end of file
+}
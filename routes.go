@@ -0,0 +1,69 @@
+package mango
+
+import "strings"
+
+// routeTable stores registered handlers and resolves a request path to the
+// handlers registered against it, along with any route parameters captured
+// from the path.
+type routeTable interface {
+	// AddHandlerFunc registers handlerFunc against pattern for method.
+	AddHandlerFunc(pattern, method string, handlerFunc ContextHandlerFunc)
+	// HandlerFuncs returns the handlers registered for the pattern that
+	// matches path, the route parameters captured from path, and whether
+	// a match was found.
+	HandlerFuncs(path string) (map[string]ContextHandlerFunc, map[string]string, bool)
+}
+
+// tree is the default routeTable implementation. Patterns may contain
+// ":name" segments which are captured as route parameters.
+type tree struct {
+	routes map[string]map[string]ContextHandlerFunc
+}
+
+// newTree creates an empty tree.
+func newTree() *tree {
+	return &tree{routes: make(map[string]map[string]ContextHandlerFunc)}
+}
+
+func (t *tree) AddHandlerFunc(pattern, method string, handlerFunc ContextHandlerFunc) {
+	handlers, ok := t.routes[pattern]
+	if !ok {
+		handlers = make(map[string]ContextHandlerFunc)
+		t.routes[pattern] = handlers
+	}
+	handlers[method] = handlerFunc
+}
+
+func (t *tree) HandlerFuncs(path string) (map[string]ContextHandlerFunc, map[string]string, bool) {
+	if handlers, ok := t.routes[path]; ok {
+		return handlers, nil, true
+	}
+	for pattern, handlers := range t.routes {
+		if params, ok := matchPattern(pattern, path); ok {
+			return handlers, params, true
+		}
+	}
+	return nil, nil, false
+}
+
+// matchPattern compares a registered pattern against a request path
+// segment-by-segment, capturing any ":name" segments as route parameters.
+func matchPattern(pattern, path string) (map[string]string, bool) {
+	patternSegs := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathSegs := strings.Split(strings.Trim(path, "/"), "/")
+	if len(patternSegs) != len(pathSegs) {
+		return nil, false
+	}
+
+	params := make(map[string]string)
+	for i, seg := range patternSegs {
+		if strings.HasPrefix(seg, ":") {
+			params[seg[1:]] = pathSegs[i]
+			continue
+		}
+		if seg != pathSegs[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
@@ -0,0 +1,89 @@
+package mango
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// WithStream writes a chunked response body using fn, which receives the
+// Context's underlying io.Writer directly. This bypasses the usual model
+// encoding pipeline, so Respond/RespondWith should not also be used on
+// the same request. Any error returned by fn is available from Err, so a
+// ContextReturnHandlerFunc can propagate it.
+// This method returns the Response object and can be chained.
+func (r *Response) WithStream(fn func(w io.Writer) error) *Response {
+	r.context.streaming = true
+	r.err = fn(r.context.Writer)
+	return r
+}
+
+// Err returns the error, if any, produced by WithStream's stream
+// function.
+func (r *Response) Err() error {
+	return r.err
+}
+
+// SSE writes a single Server-Sent Event frame to the response: an
+// auto-incrementing "id:" line, an "event:" line naming event (omitted
+// when event is empty), and one
+// "data:" line per line of data JSON-encoded via the encoderEngine,
+// terminated by a blank line. The connection is flushed immediately if
+// the underlying ResponseWriter supports it.
+//
+// The first call sets the response headers for an event stream and marks
+// the Context as streaming, suppressing the usual model encoding
+// pipeline; subsequent calls append further events to the same
+// connection.
+func (c *Context) SSE(event string, data interface{}) error {
+	if !c.streaming {
+		c.streaming = true
+		c.Writer.Header().Set("Content-Type", "text/event-stream")
+		c.Writer.Header().Set("Cache-Control", "no-cache")
+		c.Writer.Header().Set("Connection", "keep-alive")
+	}
+
+	var buf bytes.Buffer
+	encoder, err := c.encoderEngine.GetEncoder(&buf, DefaultMediaType)
+	if err != nil {
+		return err
+	}
+	if err := encoder.Encode(data); err != nil {
+		return err
+	}
+
+	c.sseID++
+	fmt.Fprintf(c.Writer, "id: %d\n", c.sseID)
+	if event != "" {
+		fmt.Fprintf(c.Writer, "event: %s\n", event)
+	}
+	for _, line := range strings.Split(strings.TrimRight(buf.String(), "\n"), "\n") {
+		fmt.Fprintf(c.Writer, "data: %s\n", line)
+	}
+	fmt.Fprint(c.Writer, "\n")
+
+	if f, ok := c.Writer.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// ServeFile serves the file at path as the response, delegating to
+// http.ServeFile so range requests and conditional GETs are handled
+// correctly. This bypasses the usual model encoding pipeline.
+func (c *Context) ServeFile(path string) {
+	c.streaming = true
+	http.ServeFile(c.Writer, c.Request, path)
+}
+
+// ServeContent serves the content of r, named name and last modified at
+// modTime, as the response, delegating to http.ServeContent so range
+// requests and conditional GETs are handled correctly. This bypasses the
+// usual model encoding pipeline.
+func (c *Context) ServeContent(name string, modTime time.Time, r io.ReadSeeker) {
+	c.streaming = true
+	http.ServeContent(c.Writer, c.Request, name, modTime, r)
+}
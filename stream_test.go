@@ -0,0 +1,153 @@
+package mango
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithStreamWritesBodyDirectly(t *testing.T) {
+	rtr := Router{}
+	rtr.routes = newMockRoutes()
+	rtr.Get("/test", func(ctx *Context) {
+		ctx.Respond().WithStream(func(w io.Writer) error {
+			_, err := io.WriteString(w, "streamed")
+			return err
+		})
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	rtr.ServeHTTP(w, req)
+
+	want := "streamed"
+	got := w.Body.String()
+	if got != want {
+		t.Errorf("Body = %q, want %q", got, want)
+	}
+}
+
+func TestWithStreamSuppressesModelEncoding(t *testing.T) {
+	rtr := Router{}
+	rtr.routes = newMockRoutes()
+	rtr.Get("/test", func(ctx *Context) {
+		ctx.Respond().WithStream(func(w io.Writer) error {
+			_, err := io.WriteString(w, "streamed")
+			return err
+		})
+		ctx.Respond().WithModel("should not be used")
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	rtr.ServeHTTP(w, req)
+
+	want := "streamed"
+	got := w.Body.String()
+	if got != want {
+		t.Errorf("Body = %q, want %q", got, want)
+	}
+}
+
+func TestWithStreamErrIsPropagatedByReturnHandler(t *testing.T) {
+	rtr := Router{}
+	rtr.routes = newMockRoutes()
+	streamErr := errors.New("broken pipe")
+	rtr.GetR("/test", func(ctx *Context) error {
+		return ctx.Respond().WithStream(func(w io.Writer) error {
+			return streamErr
+		}).Err()
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	rtr.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+}
+
+func TestSSEWritesEventFraming(t *testing.T) {
+	rtr := Router{}
+	rtr.routes = newMockRoutes()
+	rtr.encoderEngine = newEncoderEngine()
+	rtr.Get("/test", func(ctx *Context) {
+		ctx.SSE("greeting", map[string]string{"msg": "hi"})
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	rtr.ServeHTTP(w, req)
+
+	body := w.Body.String()
+	if !strings.Contains(body, "id: 1\n") {
+		t.Errorf("Body missing id line: %q", body)
+	}
+	if !strings.Contains(body, "event: greeting\n") {
+		t.Errorf("Body missing event line: %q", body)
+	}
+	if !strings.Contains(body, `data: {"msg":"hi"}`) {
+		t.Errorf("Body missing data line: %q", body)
+	}
+	if !strings.HasSuffix(body, "\n\n") {
+		t.Errorf("Body does not end with a blank line: %q", body)
+	}
+
+	wantContentType := "text/event-stream"
+	gotContentType := w.HeaderMap.Get("Content-Type")
+	if gotContentType != wantContentType {
+		t.Errorf("Content-Type = %q, want %q", gotContentType, wantContentType)
+	}
+}
+
+func TestServeFileServesFileContent(t *testing.T) {
+	tmp, err := os.CreateTemp("", "mango-servefile-*.txt")
+	if err != nil {
+		t.Fatalf("Unable to create temp file: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+	content := "hello from disk"
+	if _, err := tmp.WriteString(content); err != nil {
+		t.Fatalf("Unable to write temp file: %v", err)
+	}
+	tmp.Close()
+
+	rtr := Router{}
+	rtr.routes = newMockRoutes()
+	rtr.Get("/test", func(ctx *Context) {
+		ctx.ServeFile(tmp.Name())
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	rtr.ServeHTTP(w, req)
+
+	got := w.Body.String()
+	if got != content {
+		t.Errorf("Body = %q, want %q", got, content)
+	}
+}
+
+func TestServeContentServesReadSeekerContent(t *testing.T) {
+	rtr := Router{}
+	rtr.routes = newMockRoutes()
+	rtr.Get("/test", func(ctx *Context) {
+		ctx.ServeContent("greeting.txt", time.Now(), strings.NewReader("hello reader"))
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	rtr.ServeHTTP(w, req)
+
+	want := "hello reader"
+	got := w.Body.String()
+	if got != want {
+		t.Errorf("Body = %q, want %q", got, want)
+	}
+}